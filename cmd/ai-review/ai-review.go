@@ -1,28 +1,52 @@
 package ai_review
 
 import (
+	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/spf13/cobra"
+	"github.com/sthembisoo/ai-review-pr/internal/config"
+	"github.com/sthembisoo/ai-review-pr/internal/gitutil"
+	"github.com/sthembisoo/ai-review-pr/internal/llm"
+	"github.com/sthembisoo/ai-review-pr/internal/publisher"
+	"github.com/sthembisoo/ai-review-pr/internal/sarif"
+	"github.com/sthembisoo/ai-review-pr/internal/tui"
 )
 
 //go:embed prompt.tmpl
 var promptTemplate string
 
+// promptTemplateStdout is used for non-agentic providers (anthropic, openai,
+// ollama), which can only return text and so are asked to print bare JSON
+// instead of writing a file, unlike the agentic claude CLI.
+//
+//go:embed prompt_stdout.tmpl
+var promptTemplateStdout string
+
 var (
 	flagBranch       string
 	flagTargetBranch string
 	flagRepoPath     string
-)
-
-const (
-	claudeModelSonnet = "Sonnet"
+	flagPublish      string
+	flagPR           int
+	flagProvider     string
+	flagModel        string
+	flagTemperature  float64
+	flagMaxTokens    int
+
+	flagSARIF              string
+	flagUploadCodeScanning bool
 )
 
 func NewCmdAIReview() *cobra.Command {
@@ -38,18 +62,26 @@ Examples:
   # Specify target branch for diff (default: main)
   ai-review --repo /path/to/repo --branch feature-branch --target dev`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return start()
+			return start(cmd, cmd.Context())
 		},
 	}
 
 	cmd.Flags().StringVarP(&flagRepoPath, "repo", "r", ".", "Path to the git repository")
 	cmd.Flags().StringVarP(&flagBranch, "branch", "b", "", "Branch to review (defaults to current branch)")
-	cmd.Flags().StringVarP(&flagTargetBranch, "target", "t", "main", "Target branch to diff against")
+	cmd.Flags().StringVarP(&flagTargetBranch, "target", "t", "", "Target branch to diff against (default: main)")
+	cmd.Flags().StringVar(&flagPublish, "publish", "", "Publish the review as inline PR comments instead of writing Review.md (github, gitlab, or gitea)")
+	cmd.Flags().IntVar(&flagPR, "pr", 0, "Pull/merge request number to publish to (auto-detected from the branch if omitted)")
+	cmd.Flags().StringVar(&flagProvider, "provider", "", "LLM provider to use (claude, anthropic, openai, ollama, or openai-compatible)")
+	cmd.Flags().StringVar(&flagModel, "model", "", "Model name to request from the provider (defaults to the provider's own default)")
+	cmd.Flags().Float64Var(&flagTemperature, "temperature", 0, "Sampling temperature to request from the provider")
+	cmd.Flags().IntVar(&flagMaxTokens, "max-tokens", 0, "Maximum tokens to request from the provider (defaults to the provider's own default)")
+	cmd.Flags().StringVar(&flagSARIF, "sarif", "", "Write findings as a SARIF report to this path")
+	cmd.Flags().BoolVar(&flagUploadCodeScanning, "upload-code-scanning", false, "Upload the SARIF report to the GitHub code-scanning API (requires GITHUB_TOKEN)")
 
 	return cmd
 }
 
-func start() error {
+func start(cmd *cobra.Command, ctx context.Context) error {
 	// Resolve repo path
 	repoPath, err := filepath.Abs(flagRepoPath)
 	if err != nil {
@@ -61,21 +93,45 @@ func start() error {
 		return fmt.Errorf("not a git repository: %s", repoPath)
 	}
 
+	cfg, err := config.Load(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	flags := cmd.Flags()
+	targetBranch := config.ResolveString(flags.Changed("target"), flagTargetBranch, "AI_REVIEW_TARGET_BRANCH", cfg.TargetBranch, "main")
+	provider := config.ResolveString(flags.Changed("provider"), flagProvider, "AI_REVIEW_PROVIDER", cfg.Provider, llm.Default)
+	model := config.ResolveString(flags.Changed("model"), flagModel, "AI_REVIEW_MODEL", cfg.Model, "")
+	publish := config.ResolveString(flags.Changed("publish"), flagPublish, "AI_REVIEW_PUBLISH", cfg.Publish.Backend, "")
+
 	fmt.Printf("Reviewing repository: %s\n", repoPath)
 
-	// Get current branch if not specified
+	// Get current branch if not specified, offering an interactive picker
+	// when running in a terminal so the reviewer can review a branch other
+	// than the one currently checked out.
 	branchName := flagBranch
 	if branchName == "" {
 		branchName, err = getCurrentBranch(repoPath)
 		if err != nil {
 			return fmt.Errorf("failed to get current branch: %w", err)
 		}
+
+		if tui.IsInteractive() {
+			branches, err := gitutil.ListBranches(repoPath)
+			if err != nil {
+				return fmt.Errorf("failed to list branches: %w", err)
+			}
+			branchName, err = tui.SelectBranch(branchName, branches)
+			if err != nil {
+				return fmt.Errorf("failed to select branch: %w", err)
+			}
+		}
 	}
 
-	fmt.Printf("Branch: %s (comparing against %s)\n", branchName, flagTargetBranch)
+	fmt.Printf("Branch: %s (comparing against %s)\n", branchName, targetBranch)
 
 	// Get diff between current branch and target branch
-	diff, err := getDiff(repoPath, branchName, flagTargetBranch)
+	diff, err := getDiff(repoPath, branchName, targetBranch, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to get diff: %w", err)
 	}
@@ -85,9 +141,34 @@ func start() error {
 		return nil
 	}
 
-	// Launch Claude Code with the review prompt
-	if err := launchClaudeReview(repoPath, branchName, diff); err != nil {
-		return fmt.Errorf("failed to launch Claude review: %w", err)
+	// Launch the configured provider with the review prompt
+	review, err := runClaudeReview(ctx, repoPath, branchName, targetBranch, diff, provider, model, cfg.PromptTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to run review: %w", err)
+	}
+
+	if flagSARIF != "" || flagUploadCodeScanning {
+		if err := handleSARIF(repoPath, branchName, *review); err != nil {
+			return fmt.Errorf("failed to handle SARIF output: %w", err)
+		}
+	}
+
+	if publish != "" {
+		if err := publishReview(repoPath, branchName, publish, *review); err != nil {
+			return fmt.Errorf("failed to publish review: %w", err)
+		}
+		fmt.Println("Review published.")
+	} else {
+		reviewFilePath, err := writeReviewMarkdown(repoPath, *review)
+		if err != nil {
+			return fmt.Errorf("failed to write review: %w", err)
+		}
+
+		fmt.Printf("Opening review file: %s\n", reviewFilePath)
+		openCmd := exec.Command("open", reviewFilePath)
+		if err := openCmd.Run(); err != nil {
+			fmt.Printf("Warning: could not open review file: %v\n", err)
+		}
 	}
 
 	fmt.Println("Claude code review complete.")
@@ -95,112 +176,327 @@ func start() error {
 }
 
 func getCurrentBranch(repoDir string) (string, error) {
-	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-	cmd.Dir = repoDir
-	output, err := cmd.Output()
+	repo, err := git.PlainOpen(repoDir)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	if !head.Name().IsBranch() {
+		return "", fmt.Errorf("HEAD is not on a branch")
 	}
-	return strings.TrimSpace(string(output)), nil
+
+	return head.Name().Short(), nil
 }
 
-func getDiff(repoDir string, branchName string, targetBranch string) (string, error) {
+func getDiff(repoDir string, branchName string, targetBranch string, cfg *config.Config) (string, error) {
 	fmt.Printf("Getting diff between %s and %s...\n", branchName, targetBranch)
 
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
 	// Try with origin/ prefix first, fall back to local branch
 	targetRef := "origin/" + targetBranch
-
-	// Check if origin/target exists
-	checkCmd := exec.Command("git", "rev-parse", "--verify", targetRef)
-	checkCmd.Dir = repoDir
-	if err := checkCmd.Run(); err != nil {
-		// Fall back to local branch
+	targetHash, err := repo.ResolveRevision(plumbing.Revision(targetRef))
+	if err != nil {
 		targetRef = targetBranch
+		targetHash, err = repo.ResolveRevision(plumbing.Revision(targetRef))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve target branch %s: %w", targetBranch, err)
+		}
 	}
 
-	// Get the diff stats first
-	statsCmd := exec.Command("git", "diff", "--stat", targetRef+"...HEAD")
-	statsCmd.Dir = repoDir
-	statsOutput, err := statsCmd.Output()
+	branchHash, err := repo.ResolveRevision(plumbing.Revision(branchName))
 	if err != nil {
-		// Try without the three-dot syntax
-		statsCmd = exec.Command("git", "diff", "--stat", targetRef)
-		statsCmd.Dir = repoDir
-		statsOutput, err = statsCmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("failed to get diff stats: %w", err)
-		}
+		return "", fmt.Errorf("failed to resolve branch %s: %w", branchName, err)
 	}
 
-	// Get the full diff
-	diffCmd := exec.Command("git", "diff", targetRef+"...HEAD")
-	diffCmd.Dir = repoDir
-	diffOutput, err := diffCmd.Output()
+	targetCommit, err := repo.CommitObject(*targetHash)
 	if err != nil {
-		// Try without the three-dot syntax
-		diffCmd = exec.Command("git", "diff", targetRef)
-		diffCmd.Dir = repoDir
-		diffOutput, err = diffCmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("failed to get diff: %w", err)
-		}
+		return "", fmt.Errorf("failed to load target commit: %w", err)
+	}
+	branchCommit, err := repo.CommitObject(*branchHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to load branch commit: %w", err)
+	}
+
+	// Diff from the merge-base, not the target tip, so the review only sees
+	// what branchName actually changed (git diff target...branchName)
+	// instead of also picking up target's own changes since they diverged
+	// (git diff target..branchName).
+	mergeBases, err := targetCommit.MergeBase(branchCommit)
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", targetBranch, branchName, err)
+	}
+	if len(mergeBases) == 0 {
+		return "", fmt.Errorf("no merge base found between %s and %s", targetBranch, branchName)
+	}
+
+	baseTree, err := mergeBases[0].Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to load merge base tree: %w", err)
+	}
+	headTree, err := branchCommit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to load head tree: %w", err)
+	}
+
+	changes, err := baseTree.Diff(headTree)
+	if err != nil {
+		return "", fmt.Errorf("failed to diff trees: %w", err)
+	}
+
+	changes = filterChanges(changes, cfg)
+
+	patch, err := changes.Patch()
+	if err != nil {
+		return "", fmt.Errorf("failed to build patch: %w", err)
 	}
 
 	// Combine stats and diff
-	result := fmt.Sprintf("=== DIFF STATS ===\n%s\n\n=== FULL DIFF ===\n%s", string(statsOutput), string(diffOutput))
+	result := fmt.Sprintf("=== DIFF STATS ===\n%s\n\n=== FULL DIFF ===\n%s", patch.Stats().String(), patch.String())
 	return result, nil
 }
 
+// filterChanges applies a config's include/exclude globs to a tree diff.
+// With neither set, every change is kept.
+func filterChanges(changes object.Changes, cfg *config.Config) object.Changes {
+	if len(cfg.Include) == 0 && len(cfg.Exclude) == 0 {
+		return changes
+	}
+
+	var filtered object.Changes
+	for _, change := range changes {
+		path := changePath(change)
+		if len(cfg.Include) > 0 && !matchesAnyGlob(cfg.Include, path) {
+			continue
+		}
+		if matchesAnyGlob(cfg.Exclude, path) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// changePath returns the path a Change applies to, preferring the new path
+// (present for modifications and additions) over the old one (deletions).
+func changePath(change *object.Change) string {
+	if change.To.Name != "" {
+		return change.To.Name
+	}
+	return change.From.Name
+}
+
+func matchesAnyGlob(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, path); ok {
+			return true
+		}
+		if strings.HasPrefix(path, strings.TrimSuffix(pattern, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // promptData holds the data for the review prompt template
 type promptData struct {
-	BranchName     string
-	TargetBranch   string
-	Diff           string
-	ReviewFilePath string
+	BranchName       string
+	TargetBranch     string
+	Diff             string
+	FindingsFilePath string
+	ReviewFilePath   string
 }
 
-func launchClaudeReview(repoDir string, branchName string, diff string) error {
-	fmt.Println("Launching Claude Code for review...")
+// runClaudeReview runs the review prompt against the configured provider and
+// returns the structured review it produced. promptTemplatePath, if set,
+// overrides the embedded prompt template with a file path relative to
+// repoDir (config.Config.PromptTemplate).
+func runClaudeReview(ctx context.Context, repoDir string, branchName string, targetBranch string, diff string, providerName string, model string, promptTemplatePath string) (*publisher.Review, error) {
+	fmt.Printf("Launching %s for review...\n", providerName)
 
-	reviewFilePath := filepath.Join(repoDir, "Review.md")
+	findingsFilePath := filepath.Join(repoDir, "review.json")
 
 	// Build prompt from template
 	data := promptData{
-		BranchName:     branchName,
-		TargetBranch:   flagTargetBranch,
-		Diff:           diff,
-		ReviewFilePath: reviewFilePath,
+		BranchName:       branchName,
+		TargetBranch:     targetBranch,
+		Diff:             diff,
+		FindingsFilePath: findingsFilePath,
+		ReviewFilePath:   filepath.Join(repoDir, "Review.md"),
+	}
+
+	provider, err := llm.NewProvider(providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Agentic providers (the Claude CLI) are told to write findingsFilePath
+	// themselves; non-agentic providers can only return text, so they're
+	// asked for bare JSON on stdout instead. A configured prompt_template
+	// overrides either default.
+	promptSource := promptTemplateStdout
+	if provider.Agentic() {
+		promptSource = promptTemplate
+	}
+	if promptTemplatePath != "" {
+		overridden, err := os.ReadFile(filepath.Join(repoDir, promptTemplatePath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", promptTemplatePath, err)
+		}
+		promptSource = string(overridden)
 	}
 
-	tmpl, err := template.New("prompt").Parse(promptTemplate)
+	tmpl, err := template.New("prompt").Parse(promptSource)
 	if err != nil {
-		return fmt.Errorf("failed to parse prompt template: %w", err)
+		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
 	}
 
 	var promptBuf strings.Builder
 	if err := tmpl.Execute(&promptBuf, data); err != nil {
-		return fmt.Errorf("failed to execute prompt template: %w", err)
+		return nil, fmt.Errorf("failed to execute prompt template: %w", err)
 	}
 
-	// Launch claude with the prompt
-	cmd := exec.Command("claude", "--model="+claudeModelSonnet, "--dangerously-skip-permissions", "-p", promptBuf.String())
-	cmd.Dir = repoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	opts := llm.Options{Model: model, Temperature: flagTemperature, MaxTokens: flagMaxTokens, Dir: repoDir}
+	response, err := provider.Run(ctx, promptBuf.String(), opts)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to run claude: %w", err)
+	if _, err := os.Stat(findingsFilePath); !provider.Agentic() || os.IsNotExist(err) {
+		content, err := io.ReadAll(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read provider response: %w", err)
+		}
+		if err := os.WriteFile(findingsFilePath, stripJSONFence(content), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write review findings: %w", err)
+		}
 	}
 
-	// Open the review file after completion
-	if _, err := os.Stat(reviewFilePath); err == nil {
-		fmt.Printf("Opening review file: %s\n", reviewFilePath)
-		openCmd := exec.Command("open", reviewFilePath)
-		if err := openCmd.Run(); err != nil {
-			fmt.Printf("Warning: could not open review file: %v\n", err)
+	findingsJSON, err := os.ReadFile(findingsFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review findings: %w", err)
+	}
+
+	var review publisher.Review
+	if err := json.Unmarshal(findingsJSON, &review); err != nil {
+		return nil, fmt.Errorf("failed to decode review findings: %w", err)
+	}
+
+	return &review, nil
+}
+
+// stripJSONFence strips a leading/trailing ```json or ``` code fence from a
+// provider's response, in case the model wraps its JSON in one despite being
+// asked for bare output. Content without a fence is returned unchanged.
+func stripJSONFence(content []byte) []byte {
+	trimmed := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(trimmed, "```") {
+		return content
+	}
+
+	trimmed = strings.TrimPrefix(trimmed, "```json")
+	trimmed = strings.TrimPrefix(trimmed, "```")
+	trimmed = strings.TrimSuffix(trimmed, "```")
+	return []byte(strings.TrimSpace(trimmed))
+}
+
+// writeReviewMarkdown renders review as Markdown and writes it to repoDir/Review.md.
+func writeReviewMarkdown(repoDir string, review publisher.Review) (string, error) {
+	var body strings.Builder
+	fmt.Fprintf(&body, "# AI Review\n\n%s\n\n", review.Summary)
+	for _, finding := range review.Findings {
+		fmt.Fprintf(&body, "## %s:%d [%s]\n\n%s\n\n", finding.File, finding.Line, finding.Severity, finding.Comment)
+	}
+
+	reviewFilePath := filepath.Join(repoDir, "Review.md")
+	if err := os.WriteFile(reviewFilePath, []byte(body.String()), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write review file: %w", err)
+	}
+
+	return reviewFilePath, nil
+}
+
+// publishReview posts review as inline comments on the pull/merge request for branchName.
+func publishReview(repoDir, branchName string, backendName string, review publisher.Review) error {
+	backend, err := publisher.NewBackend(backendName)
+	if err != nil {
+		return err
+	}
+
+	owner, repo, err := publisher.OwnerRepo(repoDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve owner/repo: %w", err)
+	}
+
+	prNumber := flagPR
+	if prNumber == 0 {
+		prNumber, err = backend.ResolvePRNumber(owner, repo, branchName)
+		if err != nil {
+			return fmt.Errorf("failed to resolve pull request number: %w", err)
+		}
+	}
+
+	fmt.Printf("Publishing review to %s/%s#%d via %s...\n", owner, repo, prNumber, backendName)
+	return backend.Publish(owner, repo, prNumber, review)
+}
+
+// handleSARIF writes review as a SARIF report to --sarif (if set) and, if
+// --upload-code-scanning is set, uploads it to the GitHub code-scanning API
+// for the commit at the tip of branchName.
+func handleSARIF(repoDir, branchName string, review publisher.Review) error {
+	report := sarif.FromReview(review)
+
+	if flagSARIF != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal SARIF report: %w", err)
+		}
+		if err := os.WriteFile(flagSARIF, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write SARIF report: %w", err)
+		}
+		fmt.Printf("Wrote SARIF report to %s\n", flagSARIF)
+	}
+
+	if flagUploadCodeScanning {
+		owner, repo, err := publisher.OwnerRepo(repoDir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve owner/repo: %w", err)
+		}
+
+		commitSHA, err := resolveCommitSHA(repoDir, branchName)
+		if err != nil {
+			return fmt.Errorf("failed to resolve commit SHA for %s: %w", branchName, err)
+		}
+
+		fmt.Printf("Uploading SARIF report to %s/%s code-scanning for %s...\n", owner, repo, commitSHA)
+		ref := plumbing.NewBranchReferenceName(branchName).String()
+		if err := sarif.UploadToGitHubCodeScanning(owner, repo, commitSHA, ref, report); err != nil {
+			return fmt.Errorf("failed to upload SARIF report: %w", err)
 		}
 	}
 
 	return nil
 }
+
+// resolveCommitSHA returns the full commit hash branchName points to.
+func resolveCommitSHA(repoDir, branchName string) (string, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(branchName))
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
+}
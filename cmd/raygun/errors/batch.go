@@ -0,0 +1,231 @@
+package analyze
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/sthembisoo/ai-review-pr/cmd/raygun/types"
+	"github.com/sthembisoo/ai-review-pr/internal/config"
+)
+
+// runBatch processes the top error groups non-interactively: filtered by
+// --min-count/--since/--tag, sorted by occurrence count, capped at --top.
+// Already-analyzed groups are skipped via the local dedup cache so repeat
+// runs (e.g. on a cron) only do new work.
+func runBatch(ctx context.Context, repoDir, token string, app types.RaygunApplication, errorGroups []types.ErrorGroup, cfg *config.Config, provider, model string) error {
+	filtered, err := filterErrorGroups(errorGroups, flagMinCount, flagSince, flagTag)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Count > filtered[j].Count })
+
+	if flagTop > 0 && len(filtered) > flagTop {
+		filtered = filtered[:flagTop]
+	}
+
+	fmt.Printf("Batch mode: analyzing %d error group(s)\n", len(filtered))
+
+	cache, err := loadDedupeCache()
+	if err != nil {
+		return err
+	}
+
+	outputDir := flagOutputDir
+	if outputDir == "" {
+		outputDir = repoDir
+	}
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outputDir, err)
+	}
+
+	analyzed := 0
+	for _, errorGroup := range filtered {
+		hash := lastSeenHash(errorGroup.Count, errorGroup.LastOccurredOn)
+		if cache.seen(errorGroup.Identifier, hash) {
+			fmt.Printf("Skipping %s (already analyzed, unchanged)\n", errorGroup.Identifier)
+			continue
+		}
+
+		fmt.Printf("Analyzing %s: %s\n", errorGroup.Identifier, errorGroup.Message)
+
+		errorDetail, err := getErrorReportDetail(token, app.Identifier, errorGroup.Identifier)
+		if err != nil {
+			fmt.Printf("Warning: failed to fetch crash details for %s: %v\n", errorGroup.Identifier, err)
+			continue
+		}
+
+		if err := analyzeAndWrite(ctx, repoDir, outputDir, errorGroup, *errorDetail, cfg, provider, model); err != nil {
+			fmt.Printf("Warning: failed to analyze %s: %v\n", errorGroup.Identifier, err)
+			continue
+		}
+
+		cache.markSeen(errorGroup.Identifier, hash)
+		analyzed++
+	}
+
+	if err := cache.save(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Batch analysis complete: %d analyzed, %d skipped\n", analyzed, len(filtered)-analyzed)
+	return nil
+}
+
+// filterErrorGroups applies --min-count/--since/--tag to errorGroups.
+func filterErrorGroups(errorGroups []types.ErrorGroup, minCount int, since string, tag string) ([]types.ErrorGroup, error) {
+	var cutoff time.Time
+	if since != "" {
+		parsed, err := parseSince(since)
+		if err != nil {
+			return nil, err
+		}
+		cutoff = parsed
+	}
+
+	return lo.Filter(errorGroups, func(errorGroup types.ErrorGroup, _ int) bool {
+		if errorGroup.Count < minCount {
+			return false
+		}
+		if tag != "" && !lo.Contains(errorGroup.Tags, tag) {
+			return false
+		}
+		if !cutoff.IsZero() {
+			lastOccurred, err := time.Parse(time.RFC3339, errorGroup.LastOccurredOn)
+			if err != nil || lastOccurred.Before(cutoff) {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+// parseSince accepts either a duration ("24h") or a date ("2024-01-02") and
+// returns the corresponding cutoff time.
+func parseSince(since string) (time.Time, error) {
+	if duration, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	if date, err := time.Parse("2006-01-02", since); err == nil {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q (want a duration like 24h or a date like 2024-01-02)", since)
+}
+
+// analyzeAndWrite runs the crash analysis for a single error group and
+// writes it to outputDir in the configured --format.
+func analyzeAndWrite(ctx context.Context, repoDir, outputDir string, errorGroup types.ErrorGroup, errorDetail types.CrashReportDetail, cfg *config.Config, provider, model string) error {
+	analysisFilePath := filepath.Join(outputDir, fmt.Sprintf("RaygunError-%s.md", errorGroup.Identifier))
+	if flagFormat != "md" {
+		// runErrorAnalysis needs a path to hand the agentic provider (or
+		// write the response to itself); when the requested --format isn't
+		// md, that's scratch space, not the actual output, so keep it out
+		// of outputDir and clean it up once the analysis is read back.
+		tmpFile, err := os.CreateTemp("", fmt.Sprintf("RaygunError-%s-*.md", errorGroup.Identifier))
+		if err != nil {
+			return fmt.Errorf("failed to create temp analysis file: %w", err)
+		}
+		tmpFile.Close()
+		analysisFilePath = tmpFile.Name()
+		defer os.Remove(analysisFilePath)
+	}
+
+	analysis, err := runErrorAnalysis(ctx, repoDir, analysisFilePath, errorDetail, cfg.PathMappings, provider, model, cfg.PromptTemplate, true)
+	if err != nil {
+		return err
+	}
+
+	switch flagFormat {
+	case "md":
+		return nil // runErrorAnalysis already wrote analysisFilePath
+	case "json":
+		return writeJSONAnalysis(outputDir, errorGroup, analysis)
+	case "sarif":
+		return writeSARIFAnalysis(outputDir, errorGroup, analysis)
+	default:
+		return fmt.Errorf("unknown --format %q (want md, json, or sarif)", flagFormat)
+	}
+}
+
+func writeJSONAnalysis(outputDir string, errorGroup types.ErrorGroup, analysis string) error {
+	document := map[string]interface{}{
+		"identifier": errorGroup.Identifier,
+		"message":    errorGroup.Message,
+		"count":      errorGroup.Count,
+		"analysis":   analysis,
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal analysis: %w", err)
+	}
+
+	path := filepath.Join(outputDir, fmt.Sprintf("RaygunError-%s.json", errorGroup.Identifier))
+	return os.WriteFile(path, data, 0o644)
+}
+
+// minimalSARIFLog is an ad-hoc SARIF 2.1.0 document covering just the one
+// result this command produces. It is intentionally small; anything needing
+// a fuller SARIF writer should grow its own package rather than extend this.
+type minimalSARIFLog struct {
+	Schema  string            `json:"$schema"`
+	Version string            `json:"version"`
+	Runs    []minimalSARIFRun `json:"runs"`
+}
+
+type minimalSARIFRun struct {
+	Tool    minimalSARIFTool     `json:"tool"`
+	Results []minimalSARIFResult `json:"results"`
+}
+
+type minimalSARIFTool struct {
+	Driver minimalSARIFDriver `json:"driver"`
+}
+
+type minimalSARIFDriver struct {
+	Name string `json:"name"`
+}
+
+type minimalSARIFResult struct {
+	RuleID  string                 `json:"ruleId"`
+	Level   string                 `json:"level"`
+	Message minimalSARIFResultText `json:"message"`
+}
+
+type minimalSARIFResultText struct {
+	Text string `json:"text"`
+}
+
+func writeSARIFAnalysis(outputDir string, errorGroup types.ErrorGroup, analysis string) error {
+	document := minimalSARIFLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []minimalSARIFRun{
+			{
+				Tool: minimalSARIFTool{Driver: minimalSARIFDriver{Name: "ai-review-pr"}},
+				Results: []minimalSARIFResult{
+					{
+						RuleID:  "raygun-crash-analysis",
+						Level:   "warning",
+						Message: minimalSARIFResultText{Text: strings.TrimSpace(analysis)},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+
+	path := filepath.Join(outputDir, fmt.Sprintf("RaygunError-%s.sarif.json", errorGroup.Identifier))
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,71 @@
+package analyze
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// cacheFileName is the dedup cache for batch mode, keyed by error group
+// identifier so repeat runs skip groups that haven't changed since.
+const cacheFileName = "raygun-cache.json"
+
+// dedupeCache tracks the last-seen hash analyzed for each error group
+// identifier, so `--batch` re-runs skip groups that haven't changed.
+type dedupeCache struct {
+	path    string
+	seenFor map[string]string
+}
+
+func loadDedupeCache() (*dedupeCache, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+	}
+
+	cacheDir := filepath.Join(home, ".cache", "ai-review-pr")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	path := filepath.Join(cacheDir, cacheFileName)
+	seenFor := map[string]string{}
+
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &seenFor); err != nil {
+			return nil, fmt.Errorf("failed to parse cache file %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read cache file %s: %w", path, err)
+	}
+
+	return &dedupeCache{path: path, seenFor: seenFor}, nil
+}
+
+// seen reports whether identifier was already analyzed at lastSeenHash.
+func (c *dedupeCache) seen(identifier, lastSeenHash string) bool {
+	return c.seenFor[identifier] == lastSeenHash
+}
+
+// markSeen records that identifier was analyzed at lastSeenHash.
+func (c *dedupeCache) markSeen(identifier, lastSeenHash string) {
+	c.seenFor[identifier] = lastSeenHash
+}
+
+func (c *dedupeCache) save() error {
+	data, err := json.MarshalIndent(c.seenFor, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write cache file %s: %w", c.path, err)
+	}
+	return nil
+}
+
+// lastSeenHash derives the dedup key for an error group from its count and
+// last-occurred timestamp, so a group is re-analyzed once it changes.
+func lastSeenHash(count int, lastOccurredOn string) string {
+	return fmt.Sprintf("%d:%s", count, lastOccurredOn)
+}
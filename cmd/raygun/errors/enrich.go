@@ -0,0 +1,178 @@
+package analyze
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sthembisoo/ai-review-pr/cmd/raygun/types"
+)
+
+// sourceContextLines is how many lines are included on either side of the
+// crashing line.
+const sourceContextLines = 5
+
+// enrichStackTrace resolves each frame in stackTrace against repoDir: the
+// source snippet around the crashing line, git blame for that line, and
+// recent commits touching the crashing method. Frames that can't be
+// resolved (e.g. vendored or third-party files) are returned with their
+// enrichment fields left empty rather than failing the whole analysis.
+func enrichStackTrace(repoDir string, stackTrace []types.StackFrame, pathMappings map[string]string) []types.EnrichedStackFrame {
+	enriched := make([]types.EnrichedStackFrame, 0, len(stackTrace))
+
+	for _, frame := range stackTrace {
+		resolvedPath := resolvePath(frame.FileName, pathMappings)
+		absPath := filepath.Join(repoDir, resolvedPath)
+
+		enrichedFrame := types.EnrichedStackFrame{
+			StackFrame:   frame,
+			ResolvedPath: resolvedPath,
+		}
+
+		if source, err := readSourceContext(absPath, frame.LineNumber); err == nil {
+			enrichedFrame.Source = source
+		}
+
+		if blame, err := blameLine(repoDir, resolvedPath, frame.LineNumber); err == nil {
+			enrichedFrame.Blame = blame
+		}
+
+		if changes, err := recentChanges(repoDir, resolvedPath, frame.MethodName); err == nil {
+			enrichedFrame.RecentChanges = changes
+		}
+
+		enriched = append(enriched, enrichedFrame)
+	}
+
+	return enriched
+}
+
+// resolvePath maps a minified/bundled stack-trace path onto its repo source
+// path, if pathMappings has an entry for it (or a prefix of it).
+func resolvePath(fileName string, pathMappings map[string]string) string {
+	if mapped, ok := pathMappings[fileName]; ok {
+		return mapped
+	}
+
+	for from, to := range pathMappings {
+		if strings.HasPrefix(fileName, from) {
+			return to + strings.TrimPrefix(fileName, from)
+		}
+	}
+
+	return fileName
+}
+
+// readSourceContext returns sourceContextLines lines of context around
+// lineNumber (1-indexed) in the file at path.
+//
+// This reads with a bufio.Reader rather than bufio.Scanner: Scanner's
+// default 64KB token limit is regularly exceeded by the minified/bundled
+// files path_mappings targets, and a line over that limit made the whole
+// frame lose its source context silently. Binary files are read on a
+// best-effort basis too — whatever bytes fall between newlines are emitted
+// verbatim, so the result may not render cleanly in the prompt.
+func readSourceContext(path string, lineNumber int) (string, error) {
+	if lineNumber <= 0 {
+		return "", fmt.Errorf("invalid line number %d", lineNumber)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	start := lineNumber - sourceContextLines
+	if start < 1 {
+		start = 1
+	}
+	end := lineNumber + sourceContextLines
+
+	var snippet strings.Builder
+	reader := bufio.NewReader(file)
+	for lineNo := 1; lineNo <= end; lineNo++ {
+		line, readErr := reader.ReadString('\n')
+		if lineNo >= start {
+			marker := "   "
+			if lineNo == lineNumber {
+				marker = ">> "
+			}
+			fmt.Fprintf(&snippet, "%s%d: %s\n", marker, lineNo, strings.TrimSuffix(line, "\n"))
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				return "", readErr
+			}
+			break
+		}
+	}
+
+	return snippet.String(), nil
+}
+
+// blameLine returns the git blame info for lineNumber in path.
+func blameLine(repoDir, path string, lineNumber int) (*types.BlameInfo, error) {
+	if lineNumber <= 0 {
+		return nil, fmt.Errorf("invalid line number %d", lineNumber)
+	}
+
+	lineRange := fmt.Sprintf("%d,%d", lineNumber, lineNumber)
+	cmd := exec.Command("git", "blame", "-L", lineRange, "--line-porcelain", "--", path)
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	blame := &types.BlameInfo{}
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for lineNo := 0; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		switch {
+		case lineNo == 0:
+			blame.Commit = strings.Fields(line)[0]
+		case strings.HasPrefix(line, "author "):
+			blame.Author = strings.TrimPrefix(line, "author ")
+		case strings.HasPrefix(line, "author-time "):
+			unixSeconds, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64)
+			if err == nil {
+				blame.Date = formatUnixDate(unixSeconds)
+			}
+		}
+	}
+
+	return blame, nil
+}
+
+// recentChanges returns one summary line per commit in the last 5 commits
+// whose diff added or removed methodName in path (git log -S<methodName>).
+func recentChanges(repoDir, path, methodName string) ([]string, error) {
+	if methodName == "" {
+		return nil, nil
+	}
+
+	cmd := exec.Command("git", "log", "-n", "5", "--pretty=format:%h %ad %s", "--date=short", "-S"+methodName, "--", path)
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := strings.TrimSpace(string(output))
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	return strings.Split(trimmed, "\n"), nil
+}
+
+func formatUnixDate(unixSeconds int64) string {
+	return time.Unix(unixSeconds, 0).UTC().Format("2006-01-02")
+}
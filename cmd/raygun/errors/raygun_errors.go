@@ -1,35 +1,58 @@
 package analyze
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/template"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-resty/resty/v2"
+	"github.com/jdx/go-netrc"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"github.com/sthembisoo/ai-review-pr/cmd/raygun/types"
+	"github.com/sthembisoo/ai-review-pr/internal/config"
+	"github.com/sthembisoo/ai-review-pr/internal/gitutil"
+	"github.com/sthembisoo/ai-review-pr/internal/llm"
+	"github.com/sthembisoo/ai-review-pr/internal/tui"
 )
 
 //go:embed prompt.tmpl
 var promptTemplate string
 
 const (
-	raygunAPIBase    = "https://api.raygun.com/v3"
-	claudeModelHaiku = "Haiku"
+	raygunAPIBase = "https://api.raygun.com/v3"
 )
 
 var (
-	raygunProject string
-	raygunToken   string
-	repoPath      string
-	branch        string
+	raygunProject  string
+	raygunToken    string
+	repoPath       string
+	branch         string
+	llmProvider    string
+	llmModel       string
+	llmTemperature float64
+	llmMaxTokens   int
+
+	flagBatch     bool
+	flagTop       int
+	flagMinCount  int
+	flagSince     string
+	flagTag       string
+	flagOutputDir string
+	flagFormat    string
 )
 
 func NewCmdRaygunErrors() *cobra.Command {
@@ -54,7 +77,7 @@ Examples:
   # Specify branch to checkout
   raygun-errors --repo /path/to/repo --token YOUR_RAYGUN_TOKEN --branch main`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return start()
+			return start(cmd, cmd.Context())
 		},
 	}
 
@@ -62,20 +85,22 @@ Examples:
 	cmd.Flags().StringVarP(&raygunToken, "token", "t", "", "Raygun API access token (or set RAYGUN_TOKEN env var)")
 	cmd.Flags().StringVarP(&repoPath, "repo", "r", ".", "Path to the git repository")
 	cmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to checkout (optional)")
+	cmd.Flags().StringVar(&llmProvider, "provider", "", "LLM provider to use (claude, anthropic, openai, ollama, or openai-compatible)")
+	cmd.Flags().StringVar(&llmModel, "model", "", "Model name to request from the provider (defaults to the provider's own default)")
+	cmd.Flags().Float64Var(&llmTemperature, "temperature", 0, "Sampling temperature to request from the provider")
+	cmd.Flags().IntVar(&llmMaxTokens, "max-tokens", 0, "Maximum tokens to request from the provider (defaults to the provider's own default)")
+	cmd.Flags().BoolVar(&flagBatch, "batch", false, "Skip interactive selection and analyze the top active error groups non-interactively")
+	cmd.Flags().IntVar(&flagTop, "top", 10, "Number of error groups to analyze in --batch mode, sorted by occurrence count")
+	cmd.Flags().IntVar(&flagMinCount, "min-count", 0, "Skip error groups with fewer than this many occurrences in --batch mode")
+	cmd.Flags().StringVar(&flagSince, "since", "", "Only analyze error groups last seen within this duration (e.g. 24h) in --batch mode")
+	cmd.Flags().StringVar(&flagTag, "tag", "", "Only analyze error groups carrying this tag in --batch mode")
+	cmd.Flags().StringVar(&flagOutputDir, "output-dir", "", "Directory to write batch analysis files to (defaults to --repo)")
+	cmd.Flags().StringVar(&flagFormat, "format", "md", "Output format for batch analysis files (md, json, or sarif)")
 
 	return cmd
 }
 
-func start() error {
-	// Get Raygun token
-	token := raygunToken
-	if token == "" {
-		token = os.Getenv("RAYGUN_TOKEN")
-	}
-	if token == "" {
-		return fmt.Errorf("raygun token required: use --token flag or set RAYGUN_TOKEN environment variable")
-	}
-
+func start(cmd *cobra.Command, ctx context.Context) error {
 	// Resolve repo path
 	absRepoPath, err := filepath.Abs(repoPath)
 	if err != nil {
@@ -87,8 +112,24 @@ func start() error {
 		return fmt.Errorf("not a git repository: %s", absRepoPath)
 	}
 
+	cfg, err := config.Load(absRepoPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	flags := cmd.Flags()
+	provider := config.ResolveString(flags.Changed("provider"), llmProvider, "AI_REVIEW_PROVIDER", cfg.Provider, llm.Default)
+	model := config.ResolveString(flags.Changed("model"), llmModel, "AI_REVIEW_MODEL", cfg.Model, "")
+	project := config.ResolveString(flags.Changed("raygun-project"), raygunProject, "RAYGUN_PROJECT", cfg.Raygun.Project, "")
+
+	// Get Raygun token
+	token := config.ResolveString(flags.Changed("token"), raygunToken, "RAYGUN_TOKEN", cfg.Raygun.Token, "")
+	if token == "" {
+		return fmt.Errorf("raygun token required: use --token flag, set RAYGUN_TOKEN, or set raygun.token in %s", config.FileName)
+	}
+
 	// Choose Raygun application (Project)
-	raygunApp, err := chooseRaygunApplication(token)
+	raygunApp, err := chooseRaygunApplication(token, project)
 	if err != nil {
 		return fmt.Errorf("error selecting project: %w", err)
 	}
@@ -116,6 +157,10 @@ func start() error {
 		return nil
 	}
 
+	if flagBatch {
+		return runBatch(ctx, absRepoPath, token, *raygunApp, activeErrorGroups, cfg, provider, model)
+	}
+
 	// Choose a crash report
 	selectedErrorGroup, err := chooseErrorGroup(activeErrorGroups)
 	if err != nil {
@@ -130,26 +175,33 @@ func start() error {
 		return fmt.Errorf("error fetching crash details: %w", err)
 	}
 
-	// Checkout branch if specified
-	if branch != "" {
-		if err := checkoutBranch(absRepoPath, branch); err != nil {
+	// Checkout branch if specified, or offer to pick one interactively
+	targetBranch := branch
+	if targetBranch == "" && tui.IsInteractive() {
+		targetBranch, err = selectBranchToCheckout(absRepoPath)
+		if err != nil {
+			return fmt.Errorf("error selecting branch: %w", err)
+		}
+	}
+	if targetBranch != "" {
+		if err := checkoutBranch(absRepoPath, targetBranch); err != nil {
 			return fmt.Errorf("error checking out branch: %w", err)
 		}
 	}
 
 	// Launch Claude Code with the crash analysis prompt
 	raygunErrorFilePath := filepath.Join(absRepoPath, "RaygunError.md")
-	err = launchClaudeErrorAnalysis(absRepoPath, raygunErrorFilePath, *errorDetail)
+	_, err = runErrorAnalysis(ctx, absRepoPath, raygunErrorFilePath, *errorDetail, cfg.PathMappings, provider, model, cfg.PromptTemplate, false)
 	if err != nil {
-		return fmt.Errorf("error launching Claude analysis: %w", err)
+		return fmt.Errorf("error running crash analysis: %w", err)
 	}
 
-	fmt.Println("\nClaude error analysis complete")
+	fmt.Println("\nCrash analysis complete")
 	return nil
 }
 
 // chooseRaygunApplication fetches and prompts user to select a Raygun application
-func chooseRaygunApplication(token string) (*types.RaygunApplication, error) {
+func chooseRaygunApplication(token string, project string) (*types.RaygunApplication, error) {
 	applications, err := fetchApplications(token, 20)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch applications: %w", err)
@@ -159,33 +211,27 @@ func chooseRaygunApplication(token string) (*types.RaygunApplication, error) {
 		return nil, fmt.Errorf("no applications found")
 	}
 
-	if raygunProject != "" {
+	if project != "" {
 		application, exists := lo.Find(applications, func(app types.RaygunApplication) bool {
-			return app.Name == raygunProject
+			return app.Name == project
 		})
 		if exists {
 			return &application, nil
 		}
-		return nil, fmt.Errorf("raygun project '%s' not found", raygunProject)
+		return nil, fmt.Errorf("raygun project '%s' not found", project)
 	}
 
-	// Build display options
-	fmt.Println("Available Raygun projects:")
+	items := make([]tui.Item, len(applications))
 	for i, app := range applications {
-		fmt.Printf("  %d. %s\n", i+1, app.Name)
+		items[i] = tui.Item{Title: app.Name}
 	}
 
-	var selection int
-	fmt.Print("\nSelect project number: ")
-	if _, err := fmt.Scanf("%d", &selection); err != nil {
-		return nil, fmt.Errorf("invalid selection: %w", err)
-	}
-
-	if selection < 1 || selection > len(applications) {
-		return nil, fmt.Errorf("selection out of range")
+	selection, err := tui.Select("Select a Raygun project", items)
+	if err != nil {
+		return nil, err
 	}
 
-	return &applications[selection-1], nil
+	return &applications[selection], nil
 }
 
 // fetchApplications retrieves all applications from Raygun API
@@ -242,27 +288,24 @@ func listErrorGroups(token string, app types.RaygunApplication) ([]types.ErrorGr
 
 // chooseErrorGroup prompts user to select an error group
 func chooseErrorGroup(errorGroups []types.ErrorGroup) (*types.ErrorGroup, error) {
-	fmt.Println("\nActive error groups:")
+	items := make([]tui.Item, len(errorGroups))
 	for i, eg := range errorGroups {
-		// Truncate message if too long
 		msg := eg.Message
 		if len(msg) > 80 {
 			msg = msg[:77] + "..."
 		}
-		fmt.Printf("  %d. [%d occurrences] %s\n", i+1, eg.Count, msg)
-	}
-
-	var selection int
-	fmt.Print("\nSelect error group number: ")
-	if _, err := fmt.Scanf("%d", &selection); err != nil {
-		return nil, fmt.Errorf("invalid selection: %w", err)
+		items[i] = tui.Item{
+			Title:       msg,
+			Description: fmt.Sprintf("%d occurrences, last seen %s, status: %s", eg.Count, eg.LastOccurredOn, eg.Status),
+		}
 	}
 
-	if selection < 1 || selection > len(errorGroups) {
-		return nil, fmt.Errorf("selection out of range")
+	selection, err := tui.Select("Select an error group", items)
+	if err != nil {
+		return nil, err
 	}
 
-	return &errorGroups[selection-1], nil
+	return &errorGroups[selection], nil
 }
 
 // getErrorReportDetail fetches detailed error information
@@ -295,74 +338,202 @@ func getErrorReportDetail(token, appIdentifier, errorGroupIdentifier string) (*t
 	return &details[0], nil
 }
 
+// selectBranchToCheckout offers an interactive branch picker for repoDir,
+// returning "" if the user chose to stay on the current branch.
+func selectBranchToCheckout(repoDir string) (string, error) {
+	current, err := getCurrentBranchName(repoDir)
+	if err != nil {
+		return "", err
+	}
+
+	branches, err := gitutil.ListBranches(repoDir)
+	if err != nil {
+		return "", err
+	}
+
+	selected, err := tui.SelectBranch(current, branches)
+	if err != nil {
+		return "", err
+	}
+	if selected == current {
+		return "", nil
+	}
+
+	return selected, nil
+}
+
+func getCurrentBranchName(repoDir string) (string, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+
+	return head.Name().Short(), nil
+}
+
 func checkoutBranch(repoDir, branchName string) error {
-	// Fetch latest
-	fetchCmd := exec.Command("git", "fetch", "--all")
-	fetchCmd.Dir = repoDir
-	fetchCmd.Run() // Ignore fetch errors
-
-	// Checkout branch
-	cmd := exec.Command("git", "checkout", branchName)
-	cmd.Dir = repoDir
-	output, err := cmd.CombinedOutput()
+	repo, err := git.PlainOpen(repoDir)
 	if err != nil {
-		return fmt.Errorf("failed to checkout branch %s: %w\n%s", branchName, err, string(output))
+		return fmt.Errorf("failed to open repository: %w", err)
 	}
 
-	// Pull latest
-	pullCmd := exec.Command("git", "pull")
-	pullCmd.Dir = repoDir
-	pullCmd.Run() // Ignore pull errors
+	auth := netrcAuth(repoDir)
+
+	// Fetch latest, ignoring errors the same way the old `git fetch --all` did
+	// (e.g. no remote configured, already up to date).
+	repo.Fetch(&git.FetchOptions{RemoteName: "origin", Auth: auth})
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to open worktree: %w", err)
+	}
+
+	checkoutOpts := &git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branchName)}
+	if err := worktree.Checkout(checkoutOpts); err != nil {
+		// worktree.Checkout only succeeds for an existing local branch; the
+		// replaced `git checkout <branch>` also auto-created a tracking
+		// branch from origin/<branch>. Fall back to that for branches that
+		// only exist on the remote.
+		remoteRef, remoteErr := repo.Reference(plumbing.NewRemoteReferenceName("origin", branchName), true)
+		if remoteErr != nil {
+			return fmt.Errorf("failed to checkout branch %s: %w", branchName, err)
+		}
+
+		createOpts := &git.CheckoutOptions{
+			Branch: plumbing.NewBranchReferenceName(branchName),
+			Hash:   remoteRef.Hash(),
+			Create: true,
+		}
+		if err := worktree.Checkout(createOpts); err != nil {
+			return fmt.Errorf("failed to checkout branch %s from origin: %w", branchName, err)
+		}
+	}
+
+	// Pull latest, ignoring errors the same way the old `git pull` did.
+	worktree.Pull(&git.PullOptions{RemoteName: "origin", Auth: auth})
 
 	fmt.Printf("Checked out branch: %s\n", branchName)
 	return nil
 }
 
+// netrcAuth builds HTTP basic auth from ~/.netrc for the repo's origin
+// remote, so private repos work without relying on an ambient git config.
+// Returns nil (anonymous auth) if there is no .netrc or no matching entry.
+func netrcAuth(repoDir string) transport.AuthMethod {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	netrcFile, err := netrc.Parse(filepath.Join(home, ".netrc"))
+	if err != nil {
+		return nil
+	}
+
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return nil
+	}
+	remote, err := repo.Remote("origin")
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return nil
+	}
+
+	remoteURL, err := url.Parse(remote.Config().URLs[0])
+	if err != nil || remoteURL.Host == "" {
+		return nil
+	}
+
+	machine := netrcFile.Machine(remoteURL.Host)
+	if machine == nil {
+		return nil
+	}
+
+	return &githttp.BasicAuth{Username: machine.Get("login"), Password: machine.Get("password")}
+}
+
 // promptData holds the data for the crash analysis prompt template
 type promptData struct {
 	ErrorMessage     string
 	CrashDetails     []byte
+	StackTrace       []types.EnrichedStackFrame
 	AnalysisFilePath string
 }
 
-func launchClaudeErrorAnalysis(repoDir, errorFilePath string, crashDetail types.CrashReportDetail) error {
-	fmt.Println("Launching Claude Code for crash analysis...")
+// runErrorAnalysis runs the crash analysis prompt against the configured
+// provider, writes the Markdown analysis to errorFilePath (opening it
+// afterward unless batch is true), and returns the analysis text.
+// promptTemplatePath, if set, overrides the embedded prompt template with a
+// file path relative to repoDir (config.Config.PromptTemplate).
+func runErrorAnalysis(ctx context.Context, repoDir, errorFilePath string, crashDetail types.CrashReportDetail, pathMappings map[string]string, providerName string, model string, promptTemplatePath string, batch bool) (string, error) {
+	fmt.Printf("Running %s for crash analysis...\n", providerName)
 
 	// Convert struct to json
 	crashDetailJson, err := json.MarshalIndent(crashDetail, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal crash detail: %w", err)
+		return "", fmt.Errorf("failed to marshal crash detail: %w", err)
 	}
 
 	data := promptData{
 		ErrorMessage:     crashDetail.Error.Message,
 		CrashDetails:     crashDetailJson,
+		StackTrace:       enrichStackTrace(repoDir, crashDetail.Error.StackTrace, pathMappings),
 		AnalysisFilePath: errorFilePath,
 	}
 
-	tmpl, err := template.New("prompt").Parse(promptTemplate)
+	promptSource := promptTemplate
+	if promptTemplatePath != "" {
+		overridden, err := os.ReadFile(filepath.Join(repoDir, promptTemplatePath))
+		if err != nil {
+			return "", fmt.Errorf("failed to read prompt template %s: %w", promptTemplatePath, err)
+		}
+		promptSource = string(overridden)
+	}
+
+	tmpl, err := template.New("prompt").Parse(promptSource)
 	if err != nil {
-		return fmt.Errorf("failed to parse prompt template: %w", err)
+		return "", fmt.Errorf("failed to parse prompt template: %w", err)
 	}
 
 	var promptBuf strings.Builder
 	if err := tmpl.Execute(&promptBuf, data); err != nil {
-		return fmt.Errorf("failed to execute prompt template: %w", err)
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
 	}
 
-	// Launch claude with the prompt
-	cmd := exec.Command("claude", "--model="+claudeModelHaiku, "--dangerously-skip-permissions", "-p", promptBuf.String())
-	cmd.Dir = repoDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	provider, err := llm.NewProvider(providerName)
+	if err != nil {
+		return "", err
+	}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to run claude: %w", err)
+	opts := llm.Options{Model: model, Temperature: llmTemperature, MaxTokens: llmMaxTokens, Dir: repoDir}
+	response, err := provider.Run(ctx, promptBuf.String(), opts)
+	if err != nil {
+		return "", err
 	}
 
-	// Open the output file after completion
-	if _, err := os.Stat(errorFilePath); err == nil {
+	// Agentic providers (the Claude CLI) write errorFilePath themselves;
+	// others only return the response text, which we write ourselves.
+	if _, err := os.Stat(errorFilePath); !provider.Agentic() || os.IsNotExist(err) {
+		content, err := io.ReadAll(response)
+		if err != nil {
+			return "", fmt.Errorf("failed to read provider response: %w", err)
+		}
+		if err := os.WriteFile(errorFilePath, content, 0o644); err != nil {
+			return "", fmt.Errorf("failed to write analysis file: %w", err)
+		}
+	}
+
+	analysis, err := os.ReadFile(errorFilePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read analysis file: %w", err)
+	}
+
+	if !batch {
 		fmt.Printf("Opening output file: %s\n", errorFilePath)
 		openCmd := exec.Command("open", errorFilePath)
 		if err := openCmd.Run(); err != nil {
@@ -370,5 +541,5 @@ func launchClaudeErrorAnalysis(repoDir, errorFilePath string, crashDetail types.
 		}
 	}
 
-	return nil
+	return string(analysis), nil
 }
@@ -8,10 +8,12 @@ type RaygunApplication struct {
 
 // ErrorGroup represents a group of similar errors in Raygun
 type ErrorGroup struct {
-	Identifier string `json:"identifier"`
-	Message    string `json:"message"`
-	Status     string `json:"status"`
-	Count      int    `json:"count"`
+	Identifier     string   `json:"identifier"`
+	Message        string   `json:"message"`
+	Status         string   `json:"status"`
+	Count          int      `json:"count"`
+	Tags           []string `json:"tags"`
+	LastOccurredOn string   `json:"lastOccurredOn"`
 }
 
 // CrashReportDetail contains detailed information about a crash
@@ -34,6 +36,34 @@ type StackFrame struct {
 	MethodName string `json:"methodName"`
 }
 
+// EnrichedStackFrame attaches the repo-relative source and history context
+// for a StackFrame, resolved at analysis time.
+type EnrichedStackFrame struct {
+	StackFrame
+
+	// ResolvedPath is FileName mapped onto a path in the repo (identity if
+	// no path mapping applied).
+	ResolvedPath string
+
+	// Source is N lines of context around LineNumber, or empty if the file
+	// or line could not be resolved.
+	Source string
+
+	// Blame is the last commit that touched LineNumber, if resolvable.
+	Blame *BlameInfo
+
+	// RecentChanges summarizes commits whose diff added or removed
+	// MethodName in ResolvedPath (git log -S<MethodName>).
+	RecentChanges []string
+}
+
+// BlameInfo is the git blame result for a single line.
+type BlameInfo struct {
+	Commit string
+	Author string
+	Date   string
+}
+
 // RequestInfo contains HTTP request information
 type RequestInfo struct {
 	URL    string `json:"url"`
@@ -0,0 +1,101 @@
+// Package config loads ai-review-pr's layered settings: a YAML policy file,
+// then environment variables, then cobra flags — flags always win. This
+// lets a repo commit a policy file (default provider/model, Raygun project,
+// publish backend, diff scoping) so day-to-day invocations don't need long
+// flag lists.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the repo-root config file ai-review-pr looks for.
+const FileName = ".ai-review.yaml"
+
+// Config is the layered settings document. Every field is optional — zero
+// values mean "not set", and callers fall back to their own defaults.
+type Config struct {
+	Provider     string `yaml:"provider"`
+	Model        string `yaml:"model"`
+	TargetBranch string `yaml:"target_branch"`
+
+	Raygun struct {
+		Token   string `yaml:"token"`
+		Project string `yaml:"project"`
+	} `yaml:"raygun"`
+
+	// Publish.Backend selects the publisher (github, gitlab, gitea). Backend
+	// tokens (GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN) are deliberately
+	// env-only, the same as the llm providers' API keys, since this file is
+	// meant to be committed to the repo.
+	Publish struct {
+		Backend string `yaml:"backend"`
+	} `yaml:"publish"`
+
+	// PromptTemplate, if set, overrides the embedded prompt template used by
+	// ai-review and raygun errors (a path relative to the repo root).
+	PromptTemplate string `yaml:"prompt_template"`
+
+	// PathMappings resolves minified/bundled stack-trace paths (e.g.
+	// "dist/main.js") onto their repo source path (e.g. "src/main.ts") for
+	// raygun errors' crash enrichment.
+	PathMappings map[string]string `yaml:"path_mappings"`
+
+	// Include/Exclude are glob patterns applied to diffed file paths.
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+}
+
+// Load reads the layered config for repoPath. It looks for FileName in the
+// repo root first, then $XDG_CONFIG_HOME/ai-review-pr/config.yaml (or
+// ~/.config/ai-review-pr/config.yaml if XDG_CONFIG_HOME is unset). It is not
+// an error for neither file to exist — Load returns a zero Config.
+func Load(repoPath string) (*Config, error) {
+	path, err := findConfigFile(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func findConfigFile(repoPath string) (string, error) {
+	repoConfig := filepath.Join(repoPath, FileName)
+	if _, err := os.Stat(repoConfig); err == nil {
+		return repoConfig, nil
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	userConfig := filepath.Join(configHome, "ai-review-pr", "config.yaml")
+	if _, err := os.Stat(userConfig); err == nil {
+		return userConfig, nil
+	}
+
+	return "", nil
+}
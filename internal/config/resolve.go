@@ -0,0 +1,18 @@
+package config
+
+import "os"
+
+// ResolveString returns the first non-empty value in priority order: an
+// explicitly-set flag, then envKey, then cfgValue, then fallback.
+func ResolveString(flagChanged bool, flagValue string, envKey string, cfgValue string, fallback string) string {
+	if flagChanged && flagValue != "" {
+		return flagValue
+	}
+	if envValue := os.Getenv(envKey); envValue != "" {
+		return envValue
+	}
+	if cfgValue != "" {
+		return cfgValue
+	}
+	return fallback
+}
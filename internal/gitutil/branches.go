@@ -0,0 +1,38 @@
+// Package gitutil holds small git helpers shared by the ai-review and
+// raygun errors commands, on top of go-git.
+package gitutil
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ListBranches returns the local branch names in repoDir, sorted
+// alphabetically.
+func ListBranches(repoDir string) ([]string, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	branchIter, err := repo.Branches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+	defer branchIter.Close()
+
+	var branches []string
+	err = branchIter.ForEach(func(ref *plumbing.Reference) error {
+		branches = append(branches, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	sort.Strings(branches)
+	return branches, nil
+}
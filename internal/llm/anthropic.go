@@ -0,0 +1,93 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	anthropicAPIBase      = "https://api.anthropic.com/v1/messages"
+	anthropicAPIVersion   = "2023-06-01"
+	anthropicDefaultModel = "claude-sonnet-4-5"
+	anthropicDefaultMax   = 4096
+)
+
+type anthropicProvider struct {
+	client *resty.Client
+}
+
+func newAnthropicProvider() (Provider, error) {
+	apiKey := os.Getenv("ANTHROPIC_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("ANTHROPIC_API_KEY environment variable is required for the anthropic provider")
+	}
+
+	client := resty.New().
+		SetHeader("x-api-key", apiKey).
+		SetHeader("anthropic-version", anthropicAPIVersion).
+		SetHeader("content-type", "application/json")
+
+	return &anthropicProvider{client: client}, nil
+}
+
+func (*anthropicProvider) Agentic() bool { return false }
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float64            `json:"temperature,omitempty"`
+	Messages    []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+func (p *anthropicProvider) Run(ctx context.Context, prompt string, opts Options) (io.Reader, error) {
+	model := opts.Model
+	if model == "" {
+		model = anthropicDefaultModel
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = anthropicDefaultMax
+	}
+
+	request := anthropicRequest{
+		Model:       model,
+		MaxTokens:   maxTokens,
+		Temperature: opts.Temperature,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt}},
+	}
+
+	response, err := p.client.R().SetContext(ctx).SetBody(request).SetResult(&anthropicResponse{}).Post(anthropicAPIBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call anthropic API: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("anthropic API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	result := response.Result().(*anthropicResponse)
+
+	var text strings.Builder
+	for _, block := range result.Content {
+		text.WriteString(block.Text)
+	}
+
+	return strings.NewReader(text.String()), nil
+}
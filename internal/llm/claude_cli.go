@@ -0,0 +1,44 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+const claudeCLIDefaultModel = "Sonnet"
+
+// claudeCLIProvider shells out to the `claude` binary, preserving the
+// original agentic behavior: the model can read/write files in opts.Dir
+// itself rather than only returning text.
+type claudeCLIProvider struct{}
+
+func newClaudeCLIProvider() Provider {
+	return claudeCLIProvider{}
+}
+
+func (claudeCLIProvider) Agentic() bool { return true }
+
+func (claudeCLIProvider) Run(ctx context.Context, prompt string, opts Options) (io.Reader, error) {
+	model := opts.Model
+	if model == "" {
+		model = claudeCLIDefaultModel
+	}
+
+	cmd := exec.CommandContext(ctx, "claude", "--model="+model, "--dangerously-skip-permissions", "-p", prompt)
+	cmd.Dir = opts.Dir
+
+	var transcript bytes.Buffer
+	cmd.Stdout = io.MultiWriter(os.Stdout, &transcript)
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run claude: %w", err)
+	}
+
+	return &transcript, nil
+}
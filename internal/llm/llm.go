@@ -0,0 +1,65 @@
+// Package llm abstracts over the different ways ai-review-pr can get a
+// completion out of a language model, so commands aren't hard-coded against
+// shelling out to the `claude` binary.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Options configures a single Run call. Zero values mean "use the provider's
+// default" — providers should not require every field to be set.
+type Options struct {
+	Model       string
+	Temperature float64
+	MaxTokens   int
+
+	// Dir is the working directory for agentic providers (e.g. the Claude
+	// CLI) that read/write files relative to the repo being reviewed.
+	Dir string
+}
+
+// Provider runs a prompt against a language model and returns its response.
+//
+// For agentic providers such as the Claude CLI, the model may act on the
+// prompt directly (e.g. writing an output file itself) rather than only
+// returning text; callers that depend on a specific output file should check
+// whether the provider already produced it before writing the returned
+// response themselves.
+type Provider interface {
+	Run(ctx context.Context, prompt string, opts Options) (io.Reader, error)
+
+	// Agentic reports whether the provider can act on the prompt directly
+	// (e.g. writing an output file itself) rather than only returning text.
+	// Callers that ask the model to produce a file should prompt agentic and
+	// non-agentic providers differently, since only the former can write one.
+	Agentic() bool
+}
+
+// Default is the provider used when no --provider flag,
+// AI_REVIEW_PROVIDER env var, or config file provider is set.
+const Default = "claude"
+
+// NewProvider returns the Provider registered under name.
+func NewProvider(name string) (Provider, error) {
+	if name == "" {
+		name = Default
+	}
+
+	switch name {
+	case "claude":
+		return newClaudeCLIProvider(), nil
+	case "anthropic":
+		return newAnthropicProvider()
+	case "openai":
+		return newOpenAIProvider()
+	case "ollama":
+		return newOllamaProvider(), nil
+	case "openai-compatible":
+		return newCompatibleProvider()
+	default:
+		return nil, fmt.Errorf("unknown llm provider %q (want claude, anthropic, openai, ollama, or openai-compatible)", name)
+	}
+}
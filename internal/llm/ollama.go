@@ -0,0 +1,68 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	ollamaDefaultHost  = "http://localhost:11434"
+	ollamaDefaultModel = "llama3"
+)
+
+type ollamaProvider struct {
+	client *resty.Client
+	host   string
+}
+
+func newOllamaProvider() Provider {
+	host := os.Getenv("OLLAMA_HOST")
+	if host == "" {
+		host = ollamaDefaultHost
+	}
+
+	return &ollamaProvider{client: resty.New(), host: host}
+}
+
+func (*ollamaProvider) Agentic() bool { return false }
+
+type ollamaRequest struct {
+	Model   string `json:"model"`
+	Prompt  string `json:"prompt"`
+	Stream  bool   `json:"stream"`
+	Options struct {
+		Temperature float64 `json:"temperature,omitempty"`
+	} `json:"options,omitempty"`
+}
+
+type ollamaResponse struct {
+	Response string `json:"response"`
+}
+
+func (p *ollamaProvider) Run(ctx context.Context, prompt string, opts Options) (io.Reader, error) {
+	model := opts.Model
+	if model == "" {
+		model = ollamaDefaultModel
+	}
+
+	request := ollamaRequest{Model: model, Prompt: prompt, Stream: false}
+	request.Options.Temperature = opts.Temperature
+
+	endpointURL := p.host + "/api/generate"
+	response, err := p.client.R().SetContext(ctx).SetBody(request).SetResult(&ollamaResponse{}).Post(endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call ollama at %s: %w", p.host, err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("ollama returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	result := response.Result().(*ollamaResponse)
+	return strings.NewReader(result.Response), nil
+}
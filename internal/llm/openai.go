@@ -0,0 +1,107 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	openAIAPIBase      = "https://api.openai.com/v1/chat/completions"
+	openAIDefaultModel = "gpt-4o"
+)
+
+// chatCompletionProvider talks to any OpenAI-compatible chat completions
+// endpoint; it backs both the "openai" and "openai-compatible" providers.
+type chatCompletionProvider struct {
+	client       *resty.Client
+	endpointURL  string
+	defaultModel string
+}
+
+func newOpenAIProvider() (Provider, error) {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY environment variable is required for the openai provider")
+	}
+
+	return &chatCompletionProvider{
+		client:       resty.New().SetHeader("Authorization", "Bearer "+apiKey),
+		endpointURL:  openAIAPIBase,
+		defaultModel: openAIDefaultModel,
+	}, nil
+}
+
+// newCompatibleProvider returns a provider for any OpenAI-compatible chat
+// completions endpoint (e.g. a local vLLM or LiteLLM gateway), configured via
+// env vars since the endpoint and auth scheme vary by deployment.
+func newCompatibleProvider() (Provider, error) {
+	endpointURL := os.Getenv("AI_REVIEW_OPENAI_COMPATIBLE_URL")
+	if endpointURL == "" {
+		return nil, fmt.Errorf("AI_REVIEW_OPENAI_COMPATIBLE_URL environment variable is required for the openai-compatible provider")
+	}
+
+	client := resty.New()
+	if apiKey := os.Getenv("AI_REVIEW_OPENAI_COMPATIBLE_API_KEY"); apiKey != "" {
+		client.SetHeader("Authorization", "Bearer "+apiKey)
+	}
+
+	return &chatCompletionProvider{
+		client:      client,
+		endpointURL: endpointURL,
+	}, nil
+}
+
+func (*chatCompletionProvider) Agentic() bool { return false }
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Temperature float64       `json:"temperature,omitempty"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Messages    []chatMessage `json:"messages"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (p *chatCompletionProvider) Run(ctx context.Context, prompt string, opts Options) (io.Reader, error) {
+	model := opts.Model
+	if model == "" {
+		model = p.defaultModel
+	}
+
+	request := chatCompletionRequest{
+		Model:       model,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+	}
+
+	response, err := p.client.R().SetContext(ctx).SetBody(request).SetResult(&chatCompletionResponse{}).Post(p.endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call chat completions endpoint: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("chat completions endpoint returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	result := response.Result().(*chatCompletionResponse)
+	if len(result.Choices) == 0 {
+		return nil, fmt.Errorf("chat completions endpoint returned no choices")
+	}
+
+	return strings.NewReader(result.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,124 @@
+package publisher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// giteaDefaultAPIBase can be overridden via GITEA_API_BASE for self-hosted instances.
+const giteaDefaultAPIBase = "https://gitea.com/api/v1"
+
+type giteaBackend struct {
+	client *resty.Client
+}
+
+func newGiteaBackend() (Backend, error) {
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITEA_TOKEN environment variable is required for the gitea publish backend")
+	}
+
+	apiBase := os.Getenv("GITEA_API_BASE")
+	if apiBase == "" {
+		apiBase = giteaDefaultAPIBase
+	}
+
+	client := resty.New().
+		SetBaseURL(apiBase).
+		SetHeader("Authorization", "token "+token)
+
+	return &giteaBackend{client: client}, nil
+}
+
+type giteaPullRequest struct {
+	Number int `json:"number"`
+	Head   struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (b *giteaBackend) ResolvePRNumber(owner, repo, branchName string) (int, error) {
+	endpointURL := fmt.Sprintf("/repos/%s/%s/pulls?state=open", owner, repo)
+
+	response, err := b.client.R().Get(endpointURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("gitea API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	var pulls []giteaPullRequest
+	if err := json.Unmarshal(response.Body(), &pulls); err != nil {
+		return 0, fmt.Errorf("failed to decode pull requests: %w", err)
+	}
+
+	for _, pull := range pulls {
+		if pull.Head.Ref == branchName {
+			return pull.Number, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no open pull request found for branch %q", branchName)
+}
+
+type giteaReviewComment struct {
+	Path        string `json:"path"`
+	Body        string `json:"body"`
+	NewPosition int    `json:"new_position"`
+}
+
+type giteaReviewRequest struct {
+	CommitID string               `json:"commit_id"`
+	Body     string               `json:"body"`
+	Event    string               `json:"event"`
+	Comments []giteaReviewComment `json:"comments"`
+}
+
+func (b *giteaBackend) Publish(owner, repo string, prNumber int, review Review) error {
+	pullURL := fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	pullResponse, err := b.client.R().Get(pullURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull request #%d: %w", prNumber, err)
+	}
+	if pullResponse.StatusCode() != http.StatusOK {
+		return fmt.Errorf("gitea API returned status %d: %s", pullResponse.StatusCode(), string(pullResponse.Body()))
+	}
+
+	var pull giteaPullRequest
+	if err := json.Unmarshal(pullResponse.Body(), &pull); err != nil {
+		return fmt.Errorf("failed to decode pull request: %w", err)
+	}
+
+	comments := make([]giteaReviewComment, 0, len(review.Findings))
+	for _, finding := range review.Findings {
+		comments = append(comments, giteaReviewComment{
+			Path:        finding.File,
+			Body:        fmt.Sprintf("**[%s]** %s", finding.Severity, finding.Comment),
+			NewPosition: finding.Line,
+		})
+	}
+
+	reviewRequest := giteaReviewRequest{
+		CommitID: pull.Head.SHA,
+		Body:     review.Summary,
+		Event:    "COMMENT",
+		Comments: comments,
+	}
+
+	reviewsURL := fmt.Sprintf("%s/reviews", pullURL)
+	response, err := b.client.R().SetBody(reviewRequest).Post(reviewsURL)
+	if err != nil {
+		return fmt.Errorf("failed to post review: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK && response.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("gitea API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package publisher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const githubAPIBase = "https://api.github.com"
+
+type githubBackend struct {
+	client *resty.Client
+}
+
+func newGitHubBackend() (Backend, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN environment variable is required for the github publish backend")
+	}
+
+	client := resty.New().
+		SetHeader("Authorization", "Bearer "+token).
+		SetHeader("Accept", "application/vnd.github+json")
+
+	return &githubBackend{client: client}, nil
+}
+
+type githubPullRequest struct {
+	Number int `json:"number"`
+	Head   struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (b *githubBackend) ResolvePRNumber(owner, repo, branchName string) (int, error) {
+	endpointURL := fmt.Sprintf("%s/repos/%s/%s/pulls?state=open&head=%s:%s", githubAPIBase, owner, repo, owner, branchName)
+
+	response, err := b.client.R().Get(endpointURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("github API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	var pulls []githubPullRequest
+	if err := json.Unmarshal(response.Body(), &pulls); err != nil {
+		return 0, fmt.Errorf("failed to decode pull requests: %w", err)
+	}
+	if len(pulls) == 0 {
+		return 0, fmt.Errorf("no open pull request found for branch %q", branchName)
+	}
+
+	return pulls[0].Number, nil
+}
+
+type githubReviewComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Body string `json:"body"`
+}
+
+type githubReviewRequest struct {
+	CommitID string                `json:"commit_id"`
+	Body     string                `json:"body"`
+	Event    string                `json:"event"`
+	Comments []githubReviewComment `json:"comments"`
+}
+
+func (b *githubBackend) Publish(owner, repo string, prNumber int, review Review) error {
+	pullURL := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", githubAPIBase, owner, repo, prNumber)
+	pullResponse, err := b.client.R().Get(pullURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch pull request #%d: %w", prNumber, err)
+	}
+	if pullResponse.StatusCode() != http.StatusOK {
+		return fmt.Errorf("github API returned status %d: %s", pullResponse.StatusCode(), string(pullResponse.Body()))
+	}
+
+	var pull githubPullRequest
+	if err := json.Unmarshal(pullResponse.Body(), &pull); err != nil {
+		return fmt.Errorf("failed to decode pull request: %w", err)
+	}
+
+	comments := make([]githubReviewComment, 0, len(review.Findings))
+	for _, finding := range review.Findings {
+		comments = append(comments, githubReviewComment{
+			Path: finding.File,
+			Line: finding.Line,
+			Body: fmt.Sprintf("**[%s]** %s", finding.Severity, finding.Comment),
+		})
+	}
+
+	reviewRequest := githubReviewRequest{
+		CommitID: pull.Head.SHA,
+		Body:     review.Summary,
+		Event:    "COMMENT",
+		Comments: comments,
+	}
+
+	reviewsURL := fmt.Sprintf("%s/reviews", pullURL)
+	response, err := b.client.R().SetBody(reviewRequest).Post(reviewsURL)
+	if err != nil {
+		return fmt.Errorf("failed to post review: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK && response.StatusCode() != http.StatusCreated {
+		return fmt.Errorf("github API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	return nil
+}
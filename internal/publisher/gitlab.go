@@ -0,0 +1,112 @@
+package publisher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const gitlabAPIBase = "https://gitlab.com/api/v4"
+
+type gitlabBackend struct {
+	client *resty.Client
+}
+
+func newGitLabBackend() (Backend, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITLAB_TOKEN environment variable is required for the gitlab publish backend")
+	}
+
+	client := resty.New().SetHeader("PRIVATE-TOKEN", token)
+
+	return &gitlabBackend{client: client}, nil
+}
+
+type gitlabMergeRequest struct {
+	IID      int `json:"iid"`
+	DiffRefs struct {
+		BaseSHA  string `json:"base_sha"`
+		StartSHA string `json:"start_sha"`
+		HeadSHA  string `json:"head_sha"`
+	} `json:"diff_refs"`
+}
+
+func (b *gitlabBackend) ResolvePRNumber(owner, repo, branchName string) (int, error) {
+	projectID := url.QueryEscape(owner + "/" + repo)
+	endpointURL := fmt.Sprintf("%s/projects/%s/merge_requests?state=opened&source_branch=%s", gitlabAPIBase, projectID, branchName)
+
+	response, err := b.client.R().Get(endpointURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list merge requests: %w", err)
+	}
+	if response.StatusCode() != http.StatusOK {
+		return 0, fmt.Errorf("gitlab API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	var mergeRequests []gitlabMergeRequest
+	if err := json.Unmarshal(response.Body(), &mergeRequests); err != nil {
+		return 0, fmt.Errorf("failed to decode merge requests: %w", err)
+	}
+	if len(mergeRequests) == 0 {
+		return 0, fmt.Errorf("no open merge request found for branch %q", branchName)
+	}
+
+	return mergeRequests[0].IID, nil
+}
+
+type gitlabDiscussionPosition struct {
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	PositionType string `json:"position_type"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+func (b *gitlabBackend) Publish(owner, repo string, prNumber int, review Review) error {
+	projectID := url.QueryEscape(owner + "/" + repo)
+	mrURL := fmt.Sprintf("%s/projects/%s/merge_requests/%d", gitlabAPIBase, projectID, prNumber)
+
+	mrResponse, err := b.client.R().Get(mrURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch merge request !%d: %w", prNumber, err)
+	}
+	if mrResponse.StatusCode() != http.StatusOK {
+		return fmt.Errorf("gitlab API returned status %d: %s", mrResponse.StatusCode(), string(mrResponse.Body()))
+	}
+
+	var mergeRequest gitlabMergeRequest
+	if err := json.Unmarshal(mrResponse.Body(), &mergeRequest); err != nil {
+		return fmt.Errorf("failed to decode merge request: %w", err)
+	}
+
+	discussionsURL := fmt.Sprintf("%s/discussions", mrURL)
+	for _, finding := range review.Findings {
+		body := map[string]interface{}{
+			"body": fmt.Sprintf("**[%s]** %s", finding.Severity, finding.Comment),
+			"position": gitlabDiscussionPosition{
+				BaseSHA:      mergeRequest.DiffRefs.BaseSHA,
+				StartSHA:     mergeRequest.DiffRefs.StartSHA,
+				HeadSHA:      mergeRequest.DiffRefs.HeadSHA,
+				PositionType: "text",
+				NewPath:      finding.File,
+				NewLine:      finding.Line,
+			},
+		}
+
+		response, err := b.client.R().SetBody(body).Post(discussionsURL)
+		if err != nil {
+			return fmt.Errorf("failed to post discussion on %s:%d: %w", finding.File, finding.Line, err)
+		}
+		if response.StatusCode() != http.StatusCreated {
+			return fmt.Errorf("gitlab API returned status %d: %s", response.StatusCode(), string(response.Body()))
+		}
+	}
+
+	return nil
+}
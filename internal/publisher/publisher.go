@@ -0,0 +1,46 @@
+// Package publisher maps a structured AI review into inline comments on a
+// forge pull/merge request (GitHub, GitLab, or Gitea).
+package publisher
+
+import "fmt"
+
+// Finding is a single review comment anchored to a file and line.
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Comment  string `json:"comment"`
+}
+
+// Review is the structured output Claude writes for a reviewed diff.
+type Review struct {
+	Summary  string    `json:"summary"`
+	Findings []Finding `json:"findings"`
+}
+
+// Backend publishes a Review as inline comments on a forge's pull/merge request.
+type Backend interface {
+	// ResolvePRNumber finds the open PR for branchName, since the review only
+	// has a branch name to go on unless the caller already knows the number.
+	ResolvePRNumber(owner, repo, branchName string) (int, error)
+	// Publish posts review's findings as inline comments on prNumber. The
+	// GitHub and Gitea backends post them as a single review whose body is
+	// the summary; the GitLab backend posts one discussion per finding and
+	// does not post the summary.
+	Publish(owner, repo string, prNumber int, review Review) error
+}
+
+// NewBackend returns the Backend for name ("github", "gitlab", or "gitea"),
+// reading its API token from the env var the forge conventionally uses.
+func NewBackend(name string) (Backend, error) {
+	switch name {
+	case "github":
+		return newGitHubBackend()
+	case "gitlab":
+		return newGitLabBackend()
+	case "gitea":
+		return newGiteaBackend()
+	default:
+		return nil, fmt.Errorf("unknown publish backend %q (want github, gitlab, or gitea)", name)
+	}
+}
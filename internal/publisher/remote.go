@@ -0,0 +1,28 @@
+package publisher
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+var remoteURLPattern = regexp.MustCompile(`(?:github\.com|gitlab\.com|[^/:]+)[:/]([^/]+)/(.+?)(?:\.git)?$`)
+
+// OwnerRepo resolves the "owner/repo" pair for repoDir's "origin" remote.
+func OwnerRepo(repoDir string) (owner string, repo string, err error) {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read origin remote: %w", err)
+	}
+
+	url := strings.TrimSpace(string(output))
+	matches := remoteURLPattern.FindStringSubmatch(url)
+	if matches == nil {
+		return "", "", fmt.Errorf("could not parse owner/repo from remote url %q", url)
+	}
+
+	return matches[1], matches[2], nil
+}
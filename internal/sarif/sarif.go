@@ -0,0 +1,112 @@
+// Package sarif converts a structured AI review into a SARIF 2.1.0 report,
+// so findings can be uploaded to GitHub's code-scanning API alongside other
+// scanners.
+package sarif
+
+import "github.com/sthembisoo/ai-review-pr/internal/publisher"
+
+// Report is a SARIF 2.1.0 log.
+type Report struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is the single tool run this package produces.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name string `json:"name"`
+}
+
+// Result is a single finding, anchored to a file and line range.
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           Region           `json:"region"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine,omitempty"`
+}
+
+const driverName = "ai-review-pr"
+
+// FromReview converts an AI review into a SARIF report, one result per
+// finding, reusing publisher.Review's existing {file, line, severity,
+// comment} shape rather than asking the model for a second, SARIF-specific
+// one. Two fields are therefore derived rather than native to the input:
+//   - StartLine and EndLine are both set to Finding.Line, since a Finding
+//     carries a single line rather than a range. If multi-line findings are
+//     wanted later, the review prompt needs to actually ask for a range —
+//     this package can't invent one.
+//   - RuleID is synthesized as "ai-review/<severity>", since a Finding has
+//     no rule identifier of its own.
+func FromReview(review publisher.Review) *Report {
+	results := make([]Result, 0, len(review.Findings))
+	for _, finding := range review.Findings {
+		results = append(results, Result{
+			RuleID:  "ai-review/" + finding.Severity,
+			Level:   sarifLevel(finding.Severity),
+			Message: Message{Text: finding.Comment},
+			Locations: []Location{
+				{
+					PhysicalLocation: PhysicalLocation{
+						ArtifactLocation: ArtifactLocation{URI: finding.File},
+						Region:           Region{StartLine: finding.Line, EndLine: finding.Line},
+					},
+				},
+			},
+		})
+	}
+
+	return &Report{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []Run{
+			{
+				Tool:    Tool{Driver: Driver{Name: driverName}},
+				Results: results,
+			},
+		},
+	}
+}
+
+// sarifLevel maps a Finding's severity to a SARIF result level. Unrecognized
+// severities fall back to "warning" rather than being dropped.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "error", "warning":
+		return severity
+	case "info":
+		return "note"
+	default:
+		return "warning"
+	}
+}
@@ -0,0 +1,74 @@
+package sarif
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const githubAPIBase = "https://api.github.com"
+
+// UploadToGitHubCodeScanning gzips and base64-encodes report, then uploads it
+// to the GitHub code-scanning API for owner/repo at commitSHA, using
+// GITHUB_TOKEN for auth.
+func UploadToGitHubCodeScanning(owner, repo, commitSHA, ref string, report *Report) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return fmt.Errorf("GITHUB_TOKEN environment variable is required to upload code-scanning results")
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SARIF report: %w", err)
+	}
+
+	encoded, err := encodeSARIF(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode SARIF report: %w", err)
+	}
+
+	client := resty.New().
+		SetHeader("Authorization", "Bearer "+token).
+		SetHeader("Accept", "application/vnd.github+json")
+
+	uploadRequest := struct {
+		CommitSHA string `json:"commit_sha"`
+		Ref       string `json:"ref"`
+		SARIF     string `json:"sarif"`
+	}{
+		CommitSHA: commitSHA,
+		Ref:       ref,
+		SARIF:     encoded,
+	}
+
+	endpointURL := fmt.Sprintf("%s/repos/%s/%s/code-scanning/sarifs", githubAPIBase, owner, repo)
+	response, err := client.R().SetBody(uploadRequest).Post(endpointURL)
+	if err != nil {
+		return fmt.Errorf("failed to upload SARIF report: %w", err)
+	}
+	if response.StatusCode() != http.StatusAccepted {
+		return fmt.Errorf("github API returned status %d: %s", response.StatusCode(), string(response.Body()))
+	}
+
+	return nil
+}
+
+// encodeSARIF gzips data and returns it base64-encoded, as required by the
+// code-scanning upload API.
+func encodeSARIF(data []byte) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,30 @@
+package tui
+
+import "fmt"
+
+// SelectBranch prompts the user to pick a branch, reused by both ai-review
+// and raygun errors. current is offered as the first entry so the user can
+// keep their existing checkout.
+func SelectBranch(current string, branches []string) (string, error) {
+	items := make([]Item, 0, len(branches)+1)
+	items = append(items, Item{
+		Title:       fmt.Sprintf("%s (current)", current),
+		Description: "stay on the current branch",
+	})
+	for _, branch := range branches {
+		if branch == current {
+			continue
+		}
+		items = append(items, Item{Title: branch})
+	}
+
+	index, err := Select("Select a branch", items)
+	if err != nil {
+		return "", err
+	}
+	if index == 0 {
+		return current, nil
+	}
+
+	return items[index].Title, nil
+}
@@ -0,0 +1,131 @@
+// Package tui provides a filterable, scrollable list selector for the CLI's
+// interactive prompts, falling back to a plain numeric prompt when stdout
+// isn't a TTY (e.g. in CI).
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+)
+
+// Item is a single entry offered to Select.
+type Item struct {
+	Title       string
+	Description string
+}
+
+type listItem struct{ Item }
+
+func (i listItem) FilterValue() string { return i.Item.Title }
+func (i listItem) Title() string       { return i.Item.Title }
+func (i listItem) Description() string { return i.Item.Description }
+
+// IsInteractive reports whether stdout is a TTY, i.e. whether Select will
+// render the bubbletea list instead of falling back to a numbered prompt.
+func IsInteractive() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// Select prompts the user to choose one of items under title, returning its
+// index. When stdout is not a TTY, it falls back to a numbered prompt read
+// with fmt.Scanf so batch/CI runs are unaffected.
+func Select(title string, items []Item) (int, error) {
+	if len(items) == 0 {
+		return 0, fmt.Errorf("no items to select from")
+	}
+
+	if !IsInteractive() {
+		return selectNumbered(title, items)
+	}
+
+	return selectInteractive(title, items)
+}
+
+// selectNumbered is the fallback prompt used when stdout is not a TTY.
+func selectNumbered(title string, items []Item) (int, error) {
+	fmt.Println(title)
+	for i, item := range items {
+		fmt.Printf("  %d. %s\n", i+1, item.Title)
+	}
+
+	var selection int
+	fmt.Print("\nSelect number: ")
+	if _, err := fmt.Scanf("%d", &selection); err != nil {
+		return 0, fmt.Errorf("invalid selection: %w", err)
+	}
+
+	if selection < 1 || selection > len(items) {
+		return 0, fmt.Errorf("selection out of range")
+	}
+
+	return selection - 1, nil
+}
+
+// selectInteractive renders a filterable bubbletea list and returns the
+// chosen index, or an error if the user aborted (Esc/Ctrl-C).
+func selectInteractive(title string, items []Item) (int, error) {
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = listItem{item}
+	}
+
+	delegate := list.NewDefaultDelegate()
+	listModel := list.New(listItems, delegate, 0, 0)
+	listModel.Title = title
+	listModel.SetShowHelp(true)
+
+	model := selectModel{list: listModel, chosen: -1}
+
+	program := tea.NewProgram(model, tea.WithAltScreen())
+	result, err := program.Run()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run selector: %w", err)
+	}
+
+	final := result.(selectModel)
+	if final.chosen < 0 {
+		return 0, fmt.Errorf("selection cancelled")
+	}
+
+	return final.chosen, nil
+}
+
+type selectModel struct {
+	list   list.Model
+	chosen int
+}
+
+func (m selectModel) Init() tea.Cmd { return nil }
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		case "enter":
+			if !m.list.SettingFilter() {
+				// GlobalIndex, not Index: Index is the cursor position within
+				// the filtered list, which no longer matches the index of
+				// items passed in once the user has typed a filter.
+				m.chosen = m.list.GlobalIndex()
+				return m, tea.Quit
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m selectModel) View() string {
+	return m.list.View()
+}
@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestSelectModelUpdateFiltered reproduces the bug where enter, pressed while
+// a filter narrows the visible items, resolved to list.Index() (the cursor's
+// position within the filtered list) instead of list.GlobalIndex() (its
+// position in the original, unfiltered items slice passed to Select).
+func TestSelectModelUpdateFiltered(t *testing.T) {
+	items := []Item{{Title: "alpha"}, {Title: "bravo"}, {Title: "charlie"}}
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = listItem{item}
+	}
+
+	listModel := list.New(listItems, list.NewDefaultDelegate(), 80, 20)
+
+	// Filter down to just "charlie", which sits at index 2 in the original
+	// items slice but would be at cursor index 0 within the filtered list.
+	listModel.SetFilterText("charlie")
+
+	model := selectModel{list: listModel, chosen: -1}
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	result := updated.(selectModel)
+
+	if result.chosen != 2 {
+		t.Fatalf("chosen = %d, want 2 (index of %q in the unfiltered items)", result.chosen, items[2].Title)
+	}
+}